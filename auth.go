@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// An authProvider is one way of getting a GitHub token. Providers that can't
+// get one return an error explaining why they were skipped.
+type authProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+type authProviderFunc func(context.Context) (string, error)
+
+func (f authProviderFunc) Token(ctx context.Context) (string, error) { return f(ctx) }
+
+var authProviders = map[string]authProvider{}
+
+func registerAuthProvider(name string, p authProvider) {
+	if _, ok := authProviders[name]; ok {
+		panic("auth provider already registered: " + name)
+	}
+	authProviders[name] = p
+}
+
+func init() {
+	registerAuthProvider("env", authProviderFunc(envToken))
+	registerAuthProvider("file", authProviderFunc(fileToken))
+	registerAuthProvider("gh", authProviderFunc(ghToken))
+	registerAuthProvider("keyring", authProviderFunc(keyringToken))
+	registerAuthProvider("device", deviceFlow{
+		clientIDEnv: "GHUNWATCH_CLIENT_ID",
+		scope:       "notifications read:user",
+	})
+	registerAuthProvider("app", deviceFlow{
+		clientIDEnv: "GHUNWATCH_APP_CLIENT_ID",
+	})
+}
+
+const defaultAuthChain = "env,file,gh,keyring,device,app"
+
+// authFlag is a flag.Value with the names of the auth providers to try, in
+// order.
+type authFlag []string
+
+func (f *authFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *authFlag) Set(s string) error {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := authProviders[name]; !ok {
+			return fmt.Errorf("unknown auth provider %q", name)
+		}
+		names = append(names, name)
+	}
+	*f = names
+	return nil
+}
+
+func authVar(fs *flag.FlagSet) *authFlag {
+	f := &authFlag{}
+	if err := f.Set(defaultAuthChain); err != nil {
+		panic(err)
+	}
+	fs.Var(f, "auth", "comma separated auth providers to try in order, overrides GHUNWATCH_AUTH")
+	return f
+}
+
+// authEnv applies GHUNWATCH_AUTH to f unless --auth was given. It must be
+// called after fs is parsed, so that a bad value is rejected like a bad
+// --auth instead of falling back to the default chain.
+func authEnv(fs *flag.FlagSet, f *authFlag) error {
+	env := os.Getenv("GHUNWATCH_AUTH")
+	if env == "" {
+		return nil
+	}
+
+	var set bool
+	fs.Visit(func(fl *flag.Flag) {
+		set = set || fl.Name == "auth"
+	})
+	if set {
+		return nil
+	}
+
+	if err := f.Set(env); err != nil {
+		return fmt.Errorf("invalid GHUNWATCH_AUTH: %w", err)
+	}
+	return nil
+}
+
+// token returns the token of the first provider in the chain that has one.
+func (f authFlag) token(ctx context.Context) (string, error) {
+	var skipped []string
+	for _, name := range f {
+		token, err := authProviders[name].Token(ctx)
+		if err == nil {
+			return token, nil
+		}
+		skipped = append(skipped, fmt.Sprintf("  %s: %v", name, err))
+	}
+	return "", fmt.Errorf("no GitHub token found:\n%s", strings.Join(skipped, "\n"))
+}
+
+func envToken(context.Context) (string, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return "", errors.New("GITHUB_TOKEN is not set")
+	}
+	return token, nil
+}
+
+func tokenFile() (string, error) {
+	if path := os.Getenv("GHUNWATCH_TOKEN_FILE"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ghunwatch", "token"), nil
+}
+
+func fileToken(context.Context) (string, error) {
+	path, err := tokenFile()
+	if err != nil {
+		return "", err
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%s does not exist", path)
+	} else if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return token, nil
+}
+
+func commandToken(ctx context.Context, name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%s is not installed", name)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", name, msg)
+		}
+		return "", fmt.Errorf("running %s: %w", name, err)
+	}
+
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", fmt.Errorf("%s returned an empty token", name)
+	}
+	return token, nil
+}
+
+func ghToken(ctx context.Context) (string, error) {
+	return commandToken(ctx, "gh", "auth", "token")
+}
+
+// keyringToken reads the token stored under the ghunwatch service in the
+// system keyring.
+func keyringToken(ctx context.Context) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return commandToken(ctx, "security", "find-generic-password", "-s", "ghunwatch", "-w")
+	case "linux", "freebsd", "openbsd":
+		return commandToken(ctx, "secret-tool", "lookup", "service", "ghunwatch")
+	default:
+		return "", fmt.Errorf("not supported on %s", runtime.GOOS)
+	}
+}
+
+// deviceFlow authenticates the user with the OAuth device flow. It works
+// both with OAuth apps and GitHub Apps, in which case the token acts on
+// behalf of the user, as ghunwatch needs.
+type deviceFlow struct {
+	clientIDEnv string
+	scope       string
+}
+
+func (d deviceFlow) Token(ctx context.Context) (string, error) {
+	clientID := os.Getenv(d.clientIDEnv)
+	if clientID == "" {
+		return "", fmt.Errorf("%s is not set", d.clientIDEnv)
+	}
+
+	if fi, err := os.Stdin.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return "", errors.New("not running in a terminal")
+	}
+
+	var code struct {
+		DeviceCode      string `json:"device_code"`
+		UserCode        string `json:"user_code"`
+		VerificationURI string `json:"verification_uri"`
+		Interval        int    `json:"interval"`
+		ExpiresIn       int    `json:"expires_in"`
+	}
+	err := postForm(ctx, "https://github.com/login/device/code", url.Values{
+		"client_id": {clientID},
+		"scope":     {d.scope},
+	}, &code)
+	if err != nil {
+		return "", fmt.Errorf("requesting device code: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Open %s and enter the code %s\n", code.VerificationURI, code.UserCode)
+
+	// RFC 8628 says to poll every 5 seconds unless told otherwise, and to
+	// back off another 5 seconds every time we're asked to slow down.
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var res struct {
+			AccessToken string `json:"access_token"`
+			Error       string `json:"error"`
+			Interval    int    `json:"interval"`
+		}
+		err := postForm(ctx, "https://github.com/login/oauth/access_token", url.Values{
+			"client_id":   {clientID},
+			"device_code": {code.DeviceCode},
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		}, &res)
+		if err != nil {
+			return "", fmt.Errorf("polling for access token: %w", err)
+		}
+
+		switch res.Error {
+		case "":
+			return res.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+			if i := time.Duration(res.Interval) * time.Second; i > interval {
+				interval = i
+			}
+		default:
+			return "", fmt.Errorf("device flow failed: %s", res.Error)
+		}
+	}
+
+	return "", errors.New("device code expired")
+}
+
+func postForm(ctx context.Context, u string, form url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestAuthEnv(t *testing.T) {
+	tests := map[string]struct {
+		env     string
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		"default":      {want: []string{"env", "file", "gh", "keyring", "device", "app"}},
+		"env":          {env: "gh, env", want: []string{"gh", "env"}},
+		"flag":         {env: "gh", args: []string{"-auth", "file"}, want: []string{"file"}},
+		"invalid env":  {env: "gh,nope", wantErr: true},
+		"flag wins":    {env: "nope", args: []string{"-auth", "env"}, want: []string{"env"}},
+		"empty env":    {env: "", want: []string{"env", "file", "gh", "keyring", "device", "app"}},
+		"invalid flag": {args: []string{"-auth", "nope"}, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("GHUNWATCH_AUTH", tt.env)
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			auth := authVar(fs)
+
+			err := fs.Parse(tt.args)
+			if err == nil {
+				err = authEnv(fs, auth)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual([]string(*auth), tt.want) {
+				t.Errorf("got %v, want %v", *auth, tt.want)
+			}
+		})
+	}
+}
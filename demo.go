@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+type demoRepo struct {
+	owner, name   string
+	archived      bool
+	pushedAt      time.Time
+	notifications int
+	active        bool
+}
+
+func demoRepos() []demoRepo {
+	now := time.Now()
+	day := 24 * time.Hour
+
+	return []demoRepo{
+		{owner: "acme", name: "api", pushedAt: now.Add(-2 * day), notifications: 12, active: true},
+		{owner: "acme", name: "infra", pushedAt: now.Add(-5 * day), notifications: 85},
+		{owner: "acme", name: "legacy-portal", archived: true, pushedAt: now.Add(-900 * day)},
+		{owner: "acme", name: "website", pushedAt: now.Add(-40 * day), notifications: 3, active: true},
+		{owner: "octo-org", name: "design-system", pushedAt: now.Add(-400 * day)},
+		{owner: "octo-org", name: "hackathon-2019", archived: true, pushedAt: now.Add(-1500 * day)},
+		{owner: "octo-org", name: "monorepo", pushedAt: now.Add(-1 * day), notifications: 140},
+		{owner: "octocat", name: "dotfiles", pushedAt: now.Add(-10 * day), active: true},
+		{owner: "octocat", name: "hello-world", pushedAt: now.Add(-700 * day)},
+		{owner: "oss-friends", name: "awesome-list", pushedAt: now.Add(-20 * day), notifications: 60},
+		{owner: "oss-friends", name: "cli", pushedAt: now.Add(-3 * day), notifications: 8, active: true},
+		{owner: "oss-friends", name: "old-plugin", pushedAt: now.Add(-800 * day)},
+	}
+}
+
+// demoServer serves the small subset of the GitHub API used by ghunwatch
+// from memory, so the tutorial goes through the same code as a real session
+// without touching anyone's subscriptions.
+type demoServer struct {
+	mu    sync.Mutex
+	repos []demoRepo
+}
+
+func newDemoClient() *github.Client {
+	ds := &demoServer{repos: demoRepos()}
+	return github.NewClient(&http.Client{Transport: countingTransport{demoTransport{ds}}})
+}
+
+// demoTransport hands requests straight to the demo server, so they never
+// leave the process.
+type demoTransport struct {
+	h http.Handler
+}
+
+func (t demoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := &demoRecorder{header: make(http.Header)}
+	t.h.ServeHTTP(rec, req)
+	if rec.code == 0 {
+		rec.code = http.StatusOK
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.code, http.StatusText(rec.code)),
+		StatusCode:    rec.code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.header,
+		Body:          io.NopCloser(&rec.body),
+		ContentLength: int64(rec.body.Len()),
+		Request:       req,
+	}, nil
+}
+
+// demoRecorder is the http.ResponseWriter given to the demo server.
+type demoRecorder struct {
+	header http.Header
+	body   bytes.Buffer
+	code   int
+}
+
+func (r *demoRecorder) Header() http.Header { return r.header }
+
+func (r *demoRecorder) WriteHeader(code int) {
+	if r.code == 0 {
+		r.code = code
+	}
+}
+
+func (r *demoRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(b)
+}
+
+func (ds *demoServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.URL.Path == "/user":
+		ds.json(w, map[string]string{"login": "octocat"})
+
+	case r.URL.Path == "/user/subscriptions":
+		ds.subscriptions(w)
+
+	case r.URL.Path == "/notifications":
+		ds.notifications(w)
+
+	case len(parts) == 3 && parts[0] == "users" && parts[2] == "events":
+		ds.events(w)
+
+	case len(parts) == 4 && parts[0] == "repos" && parts[3] == "subscription":
+		ds.subscription(w, r, parts[1], parts[2])
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (ds *demoServer) json(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (ds *demoServer) subscriptions(w http.ResponseWriter) {
+	repos := make([]*github.Repository, len(ds.repos))
+	for i, r := range ds.repos {
+		repos[i] = &github.Repository{
+			Owner:    &github.User{Login: github.String(r.owner)},
+			Name:     github.String(r.name),
+			FullName: github.String(r.owner + "/" + r.name),
+			Archived: github.Bool(r.archived),
+			PushedAt: &github.Timestamp{Time: r.pushedAt},
+		}
+	}
+	ds.json(w, repos)
+}
+
+func (ds *demoServer) notifications(w http.ResponseWriter) {
+	var ns []*github.Notification
+	for _, r := range ds.repos {
+		for i := 0; i < r.notifications; i++ {
+			ns = append(ns, &github.Notification{
+				Repository: &github.Repository{FullName: github.String(r.owner + "/" + r.name)},
+			})
+		}
+	}
+	ds.json(w, ns)
+}
+
+func (ds *demoServer) events(w http.ResponseWriter) {
+	var evs []*github.Event
+	for _, r := range ds.repos {
+		if r.active {
+			evs = append(evs, &github.Event{
+				Repo: &github.Repository{Name: github.String(r.owner + "/" + r.name)},
+			})
+		}
+	}
+	ds.json(w, evs)
+}
+
+func (ds *demoServer) subscription(w http.ResponseWriter, r *http.Request, owner, name string) {
+	i := -1
+	for j, dr := range ds.repos {
+		if dr.owner == owner && dr.name == name {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		ds.json(w, &github.Subscription{Subscribed: github.Bool(true), Ignored: github.Bool(false)})
+
+	case http.MethodDelete:
+		ds.repos = append(ds.repos[:i], ds.repos[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
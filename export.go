@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// records is the tabular data handed to exporters. Every command that
+// outputs data builds one of these so all formats are available everywhere.
+type records struct {
+	fields []string
+	rows   [][]string
+}
+
+func (r records) objects() []map[string]string {
+	objs := make([]map[string]string, len(r.rows))
+	for i, row := range r.rows {
+		obj := make(map[string]string, len(r.fields))
+		for j, f := range r.fields {
+			obj[f] = row[j]
+		}
+		objs[i] = obj
+	}
+	return objs
+}
+
+func subRecords(subs []sub) records {
+	r := records{fields: []string{"org", "repo"}}
+	for _, s := range subs {
+		r.rows = append(r.rows, []string{s.org, s.repo})
+	}
+	return r
+}
+
+type exporter interface {
+	Export(w io.Writer, r records) error
+}
+
+type exporterFunc func(io.Writer, records) error
+
+func (f exporterFunc) Export(w io.Writer, r records) error { return f(w, r) }
+
+var exporters = map[string]exporter{}
+
+func registerExporter(name string, e exporter) {
+	if _, ok := exporters[name]; ok {
+		panic("exporter already registered: " + name)
+	}
+	exporters[name] = e
+}
+
+func exporterNames() []string {
+	names := make([]string, 0, len(exporters))
+	for n := range exporters {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	registerExporter("json", exporterFunc(exportJSON))
+	registerExporter("jsonl", exporterFunc(exportJSONL))
+	registerExporter("csv", exporterFunc(exportCSV))
+	registerExporter("markdown", exporterFunc(exportMarkdown))
+	registerExporter("html", exporterFunc(exportHTML))
+}
+
+func exportJSON(w io.Writer, r records) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.objects())
+}
+
+func exportJSONL(w io.Writer, r records) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, obj := range r.objects() {
+		if err := enc.Encode(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportCSV(w io.Writer, r records) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(r.fields); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(r.rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func exportMarkdown(w io.Writer, r records) error {
+	esc := strings.NewReplacer("|", `\|`, "\n", " ")
+
+	line := func(cells []string) error {
+		escaped := make([]string, len(cells))
+		for i, c := range cells {
+			escaped[i] = esc.Replace(c)
+		}
+		_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+		return err
+	}
+
+	if err := line(r.fields); err != nil {
+		return err
+	}
+
+	sep := make([]string, len(r.fields))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	if err := line(sep); err != nil {
+		return err
+	}
+
+	for _, row := range r.rows {
+		if err := line(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportHTML(w io.Writer, r records) error {
+	var b strings.Builder
+
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for _, f := range r.fields {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(f))
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range r.rows {
+		b.WriteString("<tr>")
+		for _, c := range row {
+			fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(c))
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatFlag is a flag.Value that selects an exporter by its registered name.
+type formatFlag struct {
+	name string
+	exporter
+}
+
+func (f *formatFlag) String() string { return f.name }
+
+func (f *formatFlag) Set(name string) error {
+	e, ok := exporters[name]
+	if !ok {
+		return fmt.Errorf("unknown format %q, must be one of: %s", name, strings.Join(exporterNames(), ", "))
+	}
+	f.name, f.exporter = name, e
+	return nil
+}
+
+func formatVar(fs *flag.FlagSet, def string) *formatFlag {
+	f := &formatFlag{}
+	if err := f.Set(def); err != nil {
+		panic(err)
+	}
+	fs.Var(f, "format", "output format: "+strings.Join(exporterNames(), ", "))
+	return f
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExporters(t *testing.T) {
+	r := records{
+		fields: []string{"org", "repo"},
+		rows: [][]string{
+			{"a|b", "c,d"},
+			{"<e>", "f\ng"},
+		},
+	}
+
+	tests := map[string]string{
+		"json": `[
+  {
+    "org": "a|b",
+    "repo": "c,d"
+  },
+  {
+    "org": "<e>",
+    "repo": "f\ng"
+  }
+]
+`,
+		"jsonl": `{"org":"a|b","repo":"c,d"}
+{"org":"<e>","repo":"f\ng"}
+`,
+		"csv": "org,repo\na|b,\"c,d\"\n<e>,\"f\ng\"\n",
+		"markdown": `| org | repo |
+| --- | --- |
+| a\|b | c,d |
+| <e> | f g |
+`,
+		"html": `<table>
+<thead>
+<tr><th>org</th><th>repo</th></tr>
+</thead>
+<tbody>
+<tr><td>a|b</td><td>c,d</td></tr>
+<tr><td>&lt;e&gt;</td><td>f
+g</td></tr>
+</tbody>
+</table>
+`,
+	}
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := exporters[name].Export(&b, r); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+
+	if len(tests) != len(exporters) {
+		t.Errorf("tested %d exporters, but %d are registered", len(tests), len(exporters))
+	}
+}
+
+func TestFormatFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	f := formatVar(fs, "json")
+
+	if err := fs.Parse([]string{"-format", "csv"}); err != nil {
+		t.Fatal(err)
+	}
+	if f.name != "csv" {
+		t.Errorf("expected csv, got %s", f.name)
+	}
+
+	if err := fs.Parse([]string{"-format", "xml"}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+
+	err := writeFile(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "ok")
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(path); err != nil || string(b) != "ok" {
+		t.Fatalf("unexpected content %q, err %v", b, err)
+	}
+
+	boom := errors.New("boom")
+	err = writeFile(path, func(w io.Writer) error {
+		io.WriteString(w, "partial")
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected %v, got %v", boom, err)
+	}
+	if b, _ := os.ReadFile(path); string(b) != "ok" {
+		t.Errorf("failed export overwrote file with %q", b)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the export file, found %d entries", len(entries))
+	}
+}
@@ -8,6 +8,8 @@ require (
 	github.com/charmbracelet/lipgloss v0.4.0
 	github.com/evertras/bubble-table v0.12.1
 	github.com/google/go-github v17.0.0+incompatible
+	github.com/muesli/termenv v0.11.1-0.20220212125758-44cd13922739
+	go.starlark.net v0.0.0-20220328144851-d1966c6b9fcd
 	golang.org/x/oauth2 v0.0.0-20220411215720-9780585627b5
 )
 
@@ -21,7 +23,6 @@ require (
 	github.com/mattn/go-runewidth v0.0.13 // indirect
 	github.com/muesli/ansi v0.0.0-20211018074035-2e021307bc4b // indirect
 	github.com/muesli/reflow v0.3.0 // indirect
-	github.com/muesli/termenv v0.11.1-0.20220212125758-44cd13922739 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
 	golang.org/x/net v0.0.0-20220127200216-cd36cc0744dd // indirect
 	golang.org/x/sys v0.0.0-20211216021012-1d35b9e2eb4e // indirect
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const historyFile = "history.jsonl"
+
+// snapshot is the size of the watch list at some point in time.
+type snapshot struct {
+	Time  time.Time      `json:"time"`
+	Total int            `json:"total"`
+	Orgs  map[string]int `json:"orgs"`
+}
+
+func recordSnapshot(subs []sub) error {
+	s := snapshot{
+		Time:  time.Now().UTC(),
+		Total: len(subs),
+		Orgs:  make(map[string]int),
+	}
+	for _, sub := range subs {
+		s.Orgs[sub.org]++
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return appendState(historyFile, append(b, '\n'))
+}
+
+// record is recordSnapshot for commands, where history is best effort and
+// shouldn't make them fail.
+func record(subs []sub) {
+	if err := recordSnapshot(subs); err != nil {
+		fmt.Fprintf(os.Stderr, "recording history: %v\n", err)
+	}
+}
+
+func loadHistory() ([]snapshot, error) {
+	f, err := openState(historyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readHistory(f, os.Stderr)
+}
+
+// readHistory reads one snapshot per line. Entries that can't be parsed,
+// like one torn by a crash while it was being appended, are reported to
+// warn and skipped, so they don't break the trend forever.
+func readHistory(r io.Reader, warn io.Writer) ([]snapshot, error) {
+	var snaps []snapshot
+	br := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var s snapshot
+			if err := json.Unmarshal(line, &s); err != nil {
+				fmt.Fprintf(warn, "skipping entry %d of history: %v\n", n, err)
+			} else {
+				snaps = append(snaps, s)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return snaps, nil
+}
+
+// historyRecords has an org:NAME column per organization, or only the one
+// for org if it isn't empty. The prefix keeps organizations from clashing
+// with the time and total columns.
+func historyRecords(snaps []snapshot, org string) records {
+	orgs := make(map[string]bool)
+	if org != "" {
+		orgs[org] = true
+	} else {
+		for _, s := range snaps {
+			for o := range s.Orgs {
+				orgs[o] = true
+			}
+		}
+	}
+
+	r := records{fields: []string{"time", "total"}}
+	names := make([]string, 0, len(orgs))
+	for o := range orgs {
+		names = append(names, o)
+	}
+	sort.Strings(names)
+	for _, o := range names {
+		r.fields = append(r.fields, "org:"+o)
+	}
+
+	for _, s := range snaps {
+		row := []string{s.Time.Format(time.RFC3339), strconv.Itoa(s.Total)}
+		for _, o := range names {
+			row = append(row, strconv.Itoa(s.Orgs[o]))
+		}
+		r.rows = append(r.rows, row)
+	}
+
+	return r
+}
+
+// plotHistory draws a bar per day with the last count recorded that day.
+func plotHistory(w io.Writer, snaps []snapshot, org string) error {
+	type point struct {
+		day   string
+		count int
+	}
+
+	var points []point
+	for _, s := range snaps {
+		n := s.Total
+		if org != "" {
+			n = s.Orgs[org]
+		}
+
+		day := s.Time.Local().Format("2006-01-02")
+		if len(points) > 0 && points[len(points)-1].day == day {
+			points[len(points)-1].count = n
+		} else {
+			points = append(points, point{day, n})
+		}
+	}
+
+	max := 0
+	for _, p := range points {
+		if p.count > max {
+			max = p.count
+		}
+	}
+
+	const width = 50
+
+	for _, p := range points {
+		bar := 0
+		if max > 0 {
+			bar = p.count * width / max
+		}
+		if _, err := fmt.Fprintf(w, "%s %s %d\n", p.day, strings.Repeat("█", bar), p.count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func trendCmd(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("trend", flag.ContinueOnError)
+	stateDirVar(fs)
+	format := &formatFlag{}
+	fs.Var(format, "format", "output format instead of a chart: "+strings.Join(exporterNames(), ", "))
+	org := fs.String("org", "", "only show the repositories of this organization")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	snaps, err := loadHistory()
+	if err != nil {
+		return fmt.Errorf("loading history: %w", err)
+	}
+	if len(snaps) == 0 {
+		return errors.New("no history recorded yet, it's recorded every time your watch list is loaded")
+	}
+
+	if format.exporter != nil {
+		return format.Export(os.Stdout, historyRecords(snaps, *org))
+	}
+
+	return plotHistory(os.Stdout, snaps, *org)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadHistory(t *testing.T) {
+	bigOrgs := make(map[string]int)
+	var big strings.Builder
+	big.WriteString(`{"time":"2022-04-03T00:00:00Z","total":5000,"orgs":{`)
+	for i := 0; i < 5000; i++ {
+		if i > 0 {
+			big.WriteString(",")
+		}
+		name := fmt.Sprintf("some-rather-long-organization-name-%d", i)
+		fmt.Fprintf(&big, "%q:1", name)
+		bigOrgs[name] = 1
+	}
+	big.WriteString("}}\n")
+
+	tests := map[string]struct {
+		in       string
+		want     []int
+		warnings int
+	}{
+		"empty":              {in: "", want: nil},
+		"entries":            {in: entry(1, 3) + entry(2, 4), want: []int{3, 4}},
+		"no final newline":   {in: entry(1, 3) + strings.TrimSuffix(entry(2, 4), "\n"), want: []int{3, 4}},
+		"torn final entry":   {in: entry(1, 3) + entry(2, 4)[:20], want: []int{3}, warnings: 1},
+		"corrupt entry":      {in: entry(1, 3) + "garbage\n" + entry(3, 5), want: []int{3, 5}, warnings: 1},
+		"blank lines":        {in: entry(1, 3) + "\n\n" + entry(2, 4), want: []int{3, 4}},
+		"larger than buffer": {in: entry(1, 3) + big.String(), want: []int{3, 5000}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var warn bytes.Buffer
+			snaps, err := readHistory(strings.NewReader(tt.in), &warn)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []int
+			for _, s := range snaps {
+				got = append(got, s.Total)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got totals %v, want %v", got, tt.want)
+			}
+
+			if n := strings.Count(warn.String(), "\n"); n != tt.warnings {
+				t.Errorf("got %d warnings, want %d:\n%s", n, tt.warnings, warn.String())
+			}
+		})
+	}
+}
+
+func entry(day, total int) string {
+	return fmt.Sprintf(`{"time":"2022-04-%02dT00:00:00Z","total":%d,"orgs":{"acme":%d}}`+"\n", day, total, total)
+}
+
+func TestHistoryRecords(t *testing.T) {
+	snaps := []snapshot{
+		{Time: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), Total: 3, Orgs: map[string]int{"total": 1, "acme": 2}},
+		{Time: time.Date(2022, 4, 2, 0, 0, 0, 0, time.UTC), Total: 1, Orgs: map[string]int{"time": 1}},
+	}
+
+	tests := map[string]struct {
+		org  string
+		want records
+	}{
+		"all": {
+			want: records{
+				fields: []string{"time", "total", "org:acme", "org:time", "org:total"},
+				rows: [][]string{
+					{"2022-04-01T00:00:00Z", "3", "2", "0", "1"},
+					{"2022-04-02T00:00:00Z", "1", "0", "1", "0"},
+				},
+			},
+		},
+		"org": {
+			org: "total",
+			want: records{
+				fields: []string{"time", "total", "org:total"},
+				rows: [][]string{
+					{"2022-04-01T00:00:00Z", "3", "1"},
+					{"2022-04-02T00:00:00Z", "1", "0"},
+				},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := historyRecords(snaps, tt.org); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlotHistory(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2022, 4, d, h, 0, 0, 0, time.Local) }
+	snaps := []snapshot{
+		{Time: day(1, 9), Total: 10, Orgs: map[string]int{"acme": 4}},
+		{Time: day(1, 18), Total: 8, Orgs: map[string]int{"acme": 2}},
+		{Time: day(2, 9), Total: 4, Orgs: map[string]int{}},
+	}
+
+	tests := map[string]struct {
+		org  string
+		want string
+	}{
+		"total": {
+			want: "2022-04-01 " + strings.Repeat("█", 50) + " 8\n" +
+				"2022-04-02 " + strings.Repeat("█", 25) + " 4\n",
+		},
+		"org": {
+			org: "acme",
+			want: "2022-04-01 " + strings.Repeat("█", 50) + " 2\n" +
+				"2022-04-02  0\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := plotHistory(&buf, snaps, tt.org); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordSnapshot(t *testing.T) {
+	old := stateDir
+	stateDir = t.TempDir()
+	defer func() { stateDir = old }()
+
+	snaps, err := loadHistory()
+	if err != nil || snaps != nil {
+		t.Fatalf("got %v, %v without history, want nothing", snaps, err)
+	}
+
+	subs := []sub{{org: "acme", repo: "a"}, {org: "acme", repo: "b"}, {org: "octocat", repo: "c"}}
+	for i := 0; i < 2; i++ {
+		if err := recordSnapshot(subs[i:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snaps, err = loadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != 2 {
+		t.Fatalf("got %d snapshots, want 2", len(snaps))
+	}
+	if want := map[string]int{"acme": 2, "octocat": 1}; snaps[0].Total != 3 || !reflect.DeepEqual(snaps[0].Orgs, want) {
+		t.Errorf("got %+v, want a total of 3 and %v", snaps[0], want)
+	}
+	if want := map[string]int{"acme": 1, "octocat": 1}; snaps[1].Total != 2 || !reflect.DeepEqual(snaps[1].Orgs, want) {
+		t.Errorf("got %+v, want a total of 2 and %v", snaps[1], want)
+	}
+}
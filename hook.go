@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// hookTimeout bounds how long a hook can run, so a runaway script can't
+// leave the UI stuck.
+const hookTimeout = 30 * time.Second
+
+const (
+	actionNone    = ""
+	actionUnwatch = "unwatch"
+	actionKeep    = "keep"
+)
+
+// runHook runs a starlark script for selection logic that doesn't fit in
+// signals. The script must define decide(sub), which is called for every
+// subscription with a struct holding org, repo, archived, pushed_at and
+// signals, and must return "unwatch", "keep", or None to let the signals
+// decide.
+func runHook(ctx context.Context, path string, subs []sub, reasons map[string][]string) (map[string]string, error) {
+	// Anything the script prints would garble the UI, so it's only shown
+	// when something goes wrong.
+	var out bytes.Buffer
+	thread := &starlark.Thread{
+		Name: "hook",
+		Print: func(_ *starlark.Thread, msg string) {
+			fmt.Fprintln(&out, msg)
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel(ctx.Err().Error())
+		case <-done:
+		}
+	}()
+
+	fail := func(err error) error {
+		if out.Len() > 0 {
+			return fmt.Errorf("%w\nhook output:\n%s", err, out.String())
+		}
+		return err
+	}
+
+	globals, err := starlark.ExecFile(thread, path, nil, nil)
+	if err != nil {
+		return nil, fail(fmt.Errorf("loading hook %s: %w", path, err))
+	}
+
+	decide, ok := globals["decide"].(starlark.Callable)
+	if !ok {
+		return nil, fail(fmt.Errorf("hook %s must define a decide(sub) function", path))
+	}
+
+	actions := make(map[string]string, len(subs))
+	for _, s := range subs {
+		res, err := starlark.Call(thread, decide, starlark.Tuple{hookSub(s, reasons[s.String()])}, nil)
+		if err != nil {
+			return nil, fail(fmt.Errorf("running hook %s for %s: %w", path, s, err))
+		}
+
+		switch res := res.(type) {
+		case starlark.NoneType:
+			actions[s.String()] = actionNone
+		case starlark.String:
+			if a := string(res); a == actionUnwatch || a == actionKeep {
+				actions[s.String()] = a
+				break
+			}
+			return nil, fail(fmt.Errorf("hook %s returned unknown action %s for %s", path, res, s))
+		default:
+			return nil, fail(fmt.Errorf("hook %s returned %s instead of a string or None for %s", path, res.Type(), s))
+		}
+	}
+
+	return actions, nil
+}
+
+func hookSub(s sub, reasons []string) *starlarkstruct.Struct {
+	signals := make(starlark.Tuple, len(reasons))
+	for i, r := range reasons {
+		signals[i] = starlark.String(r)
+	}
+
+	pushedAt := ""
+	if !s.pushedAt.IsZero() {
+		pushedAt = s.pushedAt.Format(time.RFC3339)
+	}
+
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"org":       starlark.String(s.org),
+		"repo":      starlark.String(s.repo),
+		"archived":  starlark.Bool(s.archived),
+		"pushed_at": starlark.String(pushedAt),
+		"signals":   signals,
+	})
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeHook(t *testing.T, src string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "hook.star")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunHook(t *testing.T) {
+	path := writeHook(t, `
+def decide(sub):
+    if sub.org == "keep":
+        return "keep"
+    if sub.archived or "stale" in sub.signals:
+        return "unwatch"
+    return None
+`)
+
+	subs := []sub{
+		{org: "keep", repo: "a", archived: true},
+		{org: "other", repo: "archived", archived: true},
+		{org: "other", repo: "stale"},
+		{org: "other", repo: "fine"},
+	}
+	reasons := map[string][]string{"other/stale": {"stale"}}
+
+	got, err := runHook(context.Background(), path, subs, reasons)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"keep/a":         actionKeep,
+		"other/archived": actionUnwatch,
+		"other/stale":    actionUnwatch,
+		"other/fine":     actionNone,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRunHookErrors(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		want string
+	}{
+		"no decide":      {"x = 1", "must define a decide(sub) function"},
+		"unknown action": {"def decide(sub):\n    return \"drop\"", "unknown action"},
+		"wrong type":     {"def decide(sub):\n    return 1", "instead of a string or None"},
+		"output":         {"def decide(sub):\n    print(\"hi\")\n    fail(\"boom\")", "hook output:\nhi"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := runHook(context.Background(), writeHook(t, tt.src), []sub{{org: "o", repo: "r"}}, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunHookCanceled(t *testing.T) {
+	path := writeHook(t, `
+def decide(sub):
+    for i in range(1 << 30):
+        pass
+`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := runHook(ctx, path, []sub{{org: "o", repo: "r"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Errorf("got error %v, want it to be canceled", err)
+	}
+}
@@ -2,10 +2,16 @@ package main
 
 import (
 	"context"
-	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -20,7 +26,7 @@ import (
 func main() {
 	ctx := context.TODO()
 
-	if err := realMain(ctx); err != nil {
+	if err := realMain(ctx, os.Args[1:]); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -28,19 +34,300 @@ func main() {
 
 type sub struct {
 	org, repo string
+	archived  bool
+	pushedAt  time.Time
 }
 
-func realMain(ctx context.Context) error {
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		return errors.New("must set GITHUB_TOKEN")
+func (s sub) String() string {
+	return s.org + "/" + s.repo
+}
+
+type command func(ctx context.Context, args []string) error
+
+var commands = map[string]command{
+	"ui":     uiCmd,
+	"list":   listCmd,
+	"export": exportCmd,
+	"report": reportCmd,
+	"apply":  applyCmd,
+	"trend":  trendCmd,
+}
+
+func realMain(ctx context.Context, args []string) error {
+	name := "ui"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q", name)
 	}
 
-	c := github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(
+	return cmd(ctx, args)
+}
+
+func newClient(ctx context.Context, auth authFlag) (*github.Client, error) {
+	token, err := auth.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hc := oauth2.NewClient(ctx, oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
-	)))
+	))
+	hc.Transport = countingTransport{hc.Transport}
+
+	return github.NewClient(hc), nil
+}
+
+// apiCalls is the number of requests made to the GitHub API so far.
+var apiCalls int64
+
+type countingTransport struct {
+	base http.RoundTripper
+}
+
+func (t countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt64(&apiCalls, 1)
+	return t.base.RoundTrip(req)
+}
+
+func uiCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("ui", flag.ContinueOnError)
+	stateDirVar(fs)
+	auth := authVar(fs)
+	cfg := suggestFlags(fs)
+	color := &colorFlag{}
+	if err := color.Set("auto"); err != nil {
+		return err
+	}
+	fs.Var(color, "color", "color mode: auto, none, 16, 256, truecolor")
+	verify := fs.Bool("verify", false, "check that staged subscriptions didn't change before unwatching them")
+	tutorial := fs.Bool("tutorial", false, "walk through the workflow using demo data")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := authEnv(fs, auth); err != nil {
+		return err
+	}
+
+	lipgloss.SetColorProfile(color.profile)
+
+	var c *github.Client
+	if *tutorial {
+		c = newDemoClient()
+	} else {
+		var err error
+		c, err = newClient(ctx, *auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tea.NewProgram(newModel(c, *cfg, newStyles(color.profile), *verify, *tutorial)).Start()
+}
+
+func listCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	stateDirVar(fs)
+	auth := authVar(fs)
+	format := formatVar(fs, "markdown")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := authEnv(fs, auth); err != nil {
+		return err
+	}
+
+	c, err := newClient(ctx, *auth)
+	if err != nil {
+		return err
+	}
+
+	subs, err := getSubs(ctx, c)
+	if err != nil {
+		return err
+	}
+	record(subs)
+
+	return format.Export(os.Stdout, subRecords(subs))
+}
+
+func exportCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	stateDirVar(fs)
+	auth := authVar(fs)
+	format := formatVar(fs, "json")
+	out := fs.String("o", "-", "output file, - for stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := authEnv(fs, auth); err != nil {
+		return err
+	}
+
+	c, err := newClient(ctx, *auth)
+	if err != nil {
+		return err
+	}
+
+	subs, err := getSubs(ctx, c)
+	if err != nil {
+		return err
+	}
+	record(subs)
+
+	if *out == "-" {
+		return format.Export(os.Stdout, subRecords(subs))
+	}
+
+	return writeFile(*out, func(w io.Writer) error {
+		return format.Export(w, subRecords(subs))
+	})
+}
+
+// writeFile writes to a temporary file next to path and only renames it to
+// path once everything was written, so failures don't leave a partial file.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("creating export file: %w", err)
+	}
+
+	// CreateTemp uses 0600, but exports aren't any more private than a
+	// file created by os.Create would be.
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return fmt.Errorf("creating export file: %w", err)
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("writing export file: %w", err)
+	}
 
-	return tea.NewProgram(newModel(c)).Start()
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("writing export file: %w", err)
+	}
+
+	return nil
+}
+
+func reportCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	stateDirVar(fs)
+	auth := authVar(fs)
+	format := formatVar(fs, "markdown")
+	cfg := suggestFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := authEnv(fs, auth); err != nil {
+		return err
+	}
+
+	c, err := newClient(ctx, *auth)
+	if err != nil {
+		return err
+	}
+
+	subs, err := getSubs(ctx, c)
+	if err != nil {
+		return err
+	}
+	record(subs)
+
+	sugs, err := suggest(ctx, c, subs, *cfg)
+	if err != nil {
+		return err
+	}
+
+	r := records{fields: []string{"org", "repo", "signals"}}
+	for _, s := range sugs {
+		r.rows = append(r.rows, []string{s.org, s.repo, strings.Join(s.reasons, "; ")})
+	}
+
+	return format.Export(os.Stdout, r)
+}
+
+// applyCmd unwatches every suggested subscription without any interaction,
+// so a --hook script can automate the whole cleanup.
+func applyCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("apply", flag.ContinueOnError)
+	stateDirVar(fs)
+	auth := authVar(fs)
+	format := formatVar(fs, "markdown")
+	cfg := suggestFlags(fs)
+	dryRun := fs.Bool("dry-run", false, "only print what would be unwatched")
+	verify := fs.Bool("verify", false, "check that subscriptions didn't change before unwatching them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := authEnv(fs, auth); err != nil {
+		return err
+	}
+
+	c, err := newClient(ctx, *auth)
+	if err != nil {
+		return err
+	}
+
+	subs, err := getSubs(ctx, c)
+	if err != nil {
+		return err
+	}
+	record(subs)
+
+	sugs, err := suggest(ctx, c, subs, *cfg)
+	if err != nil {
+		return err
+	}
+
+	results := make(map[string]string, len(sugs))
+	var applyErr error
+	if *dryRun {
+		for _, s := range sugs {
+			results[s.String()] = "would unwatch"
+		}
+	} else {
+		toUnwatch := make([]sub, len(sugs))
+		for i, s := range sugs {
+			toUnwatch[i] = s.sub
+		}
+
+		unwatched, conflicts, err := unwatchSubs(ctx, c, toUnwatch, *verify)
+		for _, s := range unwatched {
+			results[s.String()] = "unwatched"
+		}
+		for _, cf := range conflicts {
+			results[cf.sub.String()] = "skipped: " + cf.reason
+		}
+		applyErr = err
+	}
+
+	r := records{fields: []string{"org", "repo", "signals", "result"}}
+	for _, s := range sugs {
+		result, ok := results[s.String()]
+		if !ok {
+			result = "not attempted"
+		}
+		r.rows = append(r.rows, []string{s.org, s.repo, strings.Join(s.reasons, "; "), result})
+	}
+
+	if err := format.Export(os.Stdout, r); err != nil {
+		return err
+	}
+
+	return applyErr
 }
 
 func getSubs(ctx context.Context, c *github.Client) ([]sub, error) {
@@ -61,7 +348,12 @@ func getSubs(ctx context.Context, c *github.Client) ([]sub, error) {
 		}
 
 		for _, r := range repos {
-			subs = append(subs, sub{*r.Owner.Login, *r.Name})
+			subs = append(subs, sub{
+				org:      r.GetOwner().GetLogin(),
+				repo:     r.GetName(),
+				archived: r.GetArchived(),
+				pushedAt: r.GetPushedAt().Time,
+			})
 		}
 
 		if res.NextPage == 0 {
@@ -82,9 +374,11 @@ func getSubs(ctx context.Context, c *github.Client) ([]sub, error) {
 }
 
 const (
-	colSub  = "sub"
-	colOrg  = "org"
-	colRepo = "repo"
+	colSub     = "sub"
+	colMark    = "mark"
+	colOrg     = "org"
+	colRepo    = "repo"
+	colSignals = "signals"
 )
 
 type state int
@@ -94,41 +388,225 @@ const (
 	stateError
 	stateLoaded
 	stateUnwatching
+	stateSuggesting
+	stateConflicts
+)
+
+const (
+	tabWatching = iota
+	tabStaged
+	numTabs
 )
 
 type model struct {
-	table   table.Model
-	spinner spinner.Model
-	help    help.Model
-	done    chan struct{}
-	gh      *github.Client
-	err     error
-	state   state
-}
-
-func newModel(gh *github.Client) tea.Model {
-	tbl := table.New([]table.Column{
-		table.NewFlexColumn(colOrg, "Organization", 1),
-		table.NewFlexColumn(colRepo, "Repository", 2),
-	}).SelectableRows(true).
-		WithBaseStyle(lipgloss.NewStyle().Align(lipgloss.Left))
+	tables     [numTabs]table.Model
+	tab        int
+	filtering  bool
+	spinner    spinner.Model
+	help       help.Model
+	styles     styles
+	done       chan struct{}
+	gh         *github.Client
+	err        error
+	state      state
+	subs       []sub
+	marked     map[string]bool
+	staged     map[string]bool
+	reasons    map[string][]string
+	suggestCfg suggestConfig
+	verify     bool
+	conflicts  []conflict
+	tutorial   tutorialState
+	started    time.Time
+	now        time.Time
+}
+
+func newModel(gh *github.Client, suggestCfg suggestConfig, st styles, verify, tutorial bool) tea.Model {
+	h := help.New()
+	h.Styles = st.help
+
+	now := time.Now()
 
 	m := model{
-		table:   tbl,
-		spinner: spinner.New(),
-		help:    help.New(),
-		done:    make(chan struct{}),
-		gh:      gh,
+		spinner:    spinner.New(),
+		help:       h,
+		styles:     st,
+		done:       make(chan struct{}),
+		gh:         gh,
+		marked:     make(map[string]bool),
+		staged:     make(map[string]bool),
+		reasons:    make(map[string][]string),
+		suggestCfg: suggestCfg,
+		verify:     verify,
+		tutorial:   tutorialState{enabled: tutorial},
+		started:    now,
+		now:        now,
+	}
+
+	for i := range m.tables {
+		m.tables[i] = table.New([]table.Column{
+			table.NewColumn(colMark, "", 3),
+			table.NewFlexColumn(colOrg, "Organization", 1).WithFiltered(true),
+			table.NewFlexColumn(colRepo, "Repository", 2).WithFiltered(true),
+			table.NewFlexColumn(colSignals, "Signals", 2),
+		}).WithBaseStyle(lipgloss.NewStyle().Align(lipgloss.Left)).
+			HighlightStyle(st.highlight).
+			Filtered(true)
 	}
 
 	return m
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(tea.EnterAltScreen, m.spinner.Tick, m.loadSubs)
+	return tea.Batch(tea.EnterAltScreen, m.spinner.Tick, m.loadSubs, tick())
+}
+
+type tickMsg time.Time
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func (m model) footer() string {
+	elapsed := m.now.Sub(m.started).Truncate(time.Second)
+	return m.help.Styles.ShortDesc.Render(fmt.Sprintf("%d API calls • session %s", atomic.LoadInt64(&apiCalls), elapsed))
+}
+
+func (m model) tabs() string {
+	titles := [numTabs]string{
+		tabWatching: fmt.Sprintf("Watching (%d)", len(m.subs)-len(m.staged)),
+		tabStaged:   fmt.Sprintf("Staged (%d)", len(m.staged)),
+	}
+
+	tabs := make([]string, numTabs)
+	for i, t := range titles {
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if i == m.tab {
+			style = style.Inherit(m.styles.highlight)
+		}
+		tabs[i] = style.Render(t)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+// empty explains why there's nothing to show in the current tab, so an
+// empty table doesn't look like something broke.
+func (m model) empty() string {
+	var lines []string
+	switch {
+	case len(m.rows(m.tab)) > 0:
+		return lipgloss.JoinVertical(lipgloss.Left,
+			m.tables[m.tab].View(),
+			"",
+			"No repositories match the filter.",
+			"Press / to change it, or esc to clear it.")
+
+	case m.tab == tabStaged:
+		lines = []string{
+			"Nothing staged yet.",
+			"",
+			"Mark repositories in the Watching tab with space, then press x to stage them.",
+		}
+
+	case len(m.subs) > 0:
+		lines = []string{
+			"Everything you watch is staged.",
+			"",
+			"Press tab to review the staged repositories, then c to unwatch them.",
+		}
+
+	default:
+		lines = []string{
+			"You're not watching any repositories. Nice!",
+			"",
+			"If that doesn't sound right:",
+			"",
+			"  • You might be using another account's token, try a different --auth provider.",
+			"  • Check which repositories you watch at https://github.com/watching",
+			"  • Turn off automatic watching at https://github.com/settings/notifications",
+		}
+	}
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(strings.Join(lines, "\n"))
+}
+
+func (m model) keys() []key.Binding {
+	if m.tab == tabStaged {
+		return km.StagedHelp()
+	}
+	return km.ShortHelp()
+}
+
+func (m model) rows(tab int) []table.Row {
+	var rows []table.Row
+	for _, s := range m.subs {
+		if m.staged[s.String()] != (tab == tabStaged) {
+			continue
+		}
+
+		mark := "[ ]"
+		if m.marked[s.String()] {
+			mark = "[x]"
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			colSub:     s,
+			colMark:    mark,
+			colOrg:     s.org,
+			colRepo:    s.repo,
+			colSignals: strings.Join(m.reasons[s.String()], "; "),
+		}))
+	}
+	return rows
+}
+
+// refresh rebuilds the rows of every tab. The cursor must be reset whenever
+// rows move between tabs, otherwise it might point past the last row.
+func (m *model) refresh(resetCursor bool) {
+	for i := range m.tables {
+		m.tables[i] = m.tables[i].WithRows(m.rows(i)).Focused(i == m.tab)
+		if resetCursor {
+			m.tables[i] = m.tables[i].WithHighlightedRow(0)
+		}
+	}
+}
+
+// move moves every marked subscription in the current tab to the other one.
+func (m *model) move() {
+	for _, s := range m.subs {
+		if m.marked[s.String()] && m.staged[s.String()] == (m.tab == tabStaged) {
+			m.marked[s.String()] = false
+			if m.tab == tabStaged {
+				delete(m.staged, s.String())
+			} else {
+				m.staged[s.String()] = true
+			}
+		}
+	}
+	m.refresh(true)
+}
+
+func (m model) marks() []sub {
+	var subs []sub
+	for _, s := range m.subs {
+		if m.marked[s.String()] {
+			subs = append(subs, s)
+		}
+	}
+	return subs
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	if m.tutorial.enabled {
+		m.advanceTutorial()
+	}
+	return m, cmd
+}
+
+func (m model) update(msg tea.Msg) (model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
@@ -138,15 +616,65 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.state == stateConflicts {
+			m.conflicts = nil
+			m.err = nil
+			m.state = stateLoading
+			return m, m.loadSubs
+		}
+
+		if m.state != stateLoaded {
+			if key.Matches(msg, km.Quit) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.filtering {
+			m.filtering = !key.Matches(msg, m.tables[m.tab].KeyMap().FilterBlur)
+			break
+		}
+
 		switch {
 		case key.Matches(msg, km.Quit):
 			return m, tea.Quit
 
-		case key.Matches(msg, km.Exec):
-			rows := m.table.SelectedRows()
-			subs := make([]sub, len(rows))
-			for i, r := range rows {
-				subs[i] = r.Data[colSub].(sub)
+		case key.Matches(msg, km.Filter):
+			m.filtering = true
+
+		case key.Matches(msg, km.Tab):
+			m.tab = (m.tab + 1) % numTabs
+			m.filtering = false
+			m.refresh(false)
+			return m, nil
+
+		case key.Matches(msg, km.Mark):
+			if s, ok := m.tables[m.tab].HighlightedRow().Data[colSub].(sub); ok {
+				m.marked[s.String()] = !m.marked[s.String()]
+				m.refresh(false)
+			}
+			return m, nil
+
+		case key.Matches(msg, km.Suggest):
+			if m.tab != tabWatching {
+				return m, nil
+			}
+			m.state = stateSuggesting
+			return m, m.suggest
+
+		case key.Matches(msg, km.Stage):
+			m.move()
+			return m, nil
+
+		case key.Matches(msg, km.Commit):
+			var subs []sub
+			for _, s := range m.subs {
+				if m.staged[s.String()] {
+					subs = append(subs, s)
+				}
+			}
+			if len(subs) == 0 {
+				return m, nil
 			}
 			m.state = stateUnwatching
 			return m, m.unwatch(subs)
@@ -154,7 +682,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.WindowSizeMsg:
 		hh := lipgloss.Height(m.help.ShortHelpView(km.ShortHelp()))
-		m.table = m.table.WithTargetWidth(msg.Width).WithPageSize(msg.Height - 6 - hh)
+		fh := lipgloss.Height(m.footer())
+		th := lipgloss.Height(m.tabs())
+		if m.tutorial.enabled {
+			th += lipgloss.Height(m.tutorialHint())
+		}
+		for i := range m.tables {
+			m.tables[i] = m.tables[i].WithTargetWidth(msg.Width).WithPageSize(msg.Height - 6 - hh - fh - th)
+		}
+		return m, nil
+
+	case tickMsg:
+		m.now = time.Time(msg)
+		return m, tick()
 
 	case spinner.TickMsg:
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -162,24 +702,42 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case subsLoadedMsg:
 		if msg.err != nil {
+			m.err = msg.err
 			m.state = stateError
 			return m, nil
 		}
 
-		rows := make([]table.Row, len(msg.subs))
-		for i, s := range msg.subs {
-			rows[i] = table.NewRow(table.RowData{
-				colSub:  s,
-				colOrg:  s.org,
-				colRepo: s.repo,
-			})
+		m.subs = msg.subs
+		m.marked = make(map[string]bool)
+		m.staged = make(map[string]bool)
+		m.refresh(true)
+		m.state = stateLoaded
+
+	case conflictsMsg:
+		m.conflicts = msg.conflicts
+		m.err = msg.err
+		m.state = stateConflicts
+		return m, nil
+
+	case suggestedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			m.state = stateError
+			return m, nil
 		}
 
-		m.table = m.table.WithRows(rows).Focused(true)
+		m.reasons = make(map[string][]string)
+		for _, s := range msg.sugs {
+			m.reasons[s.String()] = s.reasons
+			if !m.staged[s.String()] {
+				m.marked[s.String()] = true
+			}
+		}
+		m.refresh(false)
 		m.state = stateLoaded
 	}
 
-	m.table, cmd = m.table.Update(msg)
+	m.tables[m.tab], cmd = m.tables[m.tab].Update(msg)
 	return m, cmd
 }
 
@@ -189,15 +747,41 @@ func (m model) View() string {
 		return fmt.Sprintf("Error: %v\n", m.err)
 
 	case stateLoaded:
-		return lipgloss.JoinVertical(lipgloss.Left,
-			m.table.View(),
-			m.help.ShortHelpView(km.ShortHelp()))
+		tbl := m.tables[m.tab].View()
+		if len(m.tables[m.tab].GetVisibleRows()) == 0 {
+			tbl = m.empty()
+		}
+		views := []string{
+			m.tabs(),
+			tbl,
+			m.help.ShortHelpView(m.keys()),
+			m.footer(),
+		}
+		if m.tutorial.enabled {
+			views = append([]string{m.tutorialHint()}, views...)
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, views...)
 
 	case stateLoading:
 		return fmt.Sprintf("Loading subscriptions %s\n", m.spinner.View())
 
 	case stateUnwatching:
-		return fmt.Sprintf("Unwatching marked subscriptions %s\n", m.spinner.View())
+		return fmt.Sprintf("Unwatching staged subscriptions %s\n", m.spinner.View())
+
+	case stateSuggesting:
+		return fmt.Sprintf("Gathering signals %s\n", m.spinner.View())
+
+	case stateConflicts:
+		var b strings.Builder
+		b.WriteString("These subscriptions changed since they were loaded and were not unwatched:\n\n")
+		for _, c := range m.conflicts {
+			fmt.Fprintf(&b, "  %s: %s\n", c.sub, c.reason)
+		}
+		if m.err != nil {
+			fmt.Fprintf(&b, "\nUnwatching stopped early: %v\n", m.err)
+		}
+		b.WriteString("\nPress any key to reload.\n")
+		return b.String()
 
 	default:
 		return "Invalid state!"
@@ -213,30 +797,108 @@ func (m model) loadSubs() tea.Msg {
 	var msg subsLoadedMsg
 
 	msg.subs, msg.err = getSubs(context.TODO(), m.gh)
+	if msg.err == nil && !m.tutorial.enabled {
+		// History is best effort, and there's nowhere to report errors
+		// while the UI is running.
+		_ = recordSnapshot(msg.subs)
+	}
 
 	return msg
 }
 
-func (m model) unwatch(subs []sub) tea.Cmd {
-	ctx := context.TODO()
+type suggestedMsg struct {
+	sugs []suggestion
+	err  error
+}
+
+func (m model) suggest() tea.Msg {
+	var msg suggestedMsg
+
+	msg.sugs, msg.err = suggest(context.TODO(), m.gh, m.subs, m.suggestCfg)
+
+	return msg
+}
+
+type conflict struct {
+	sub    sub
+	reason string
+}
+
+// conflictsMsg reports the subscriptions that weren't unwatched because they
+// changed, along with the error that stopped unwatching the rest, if any.
+type conflictsMsg struct {
+	conflicts []conflict
+	err       error
+}
+
+// checkSubscription returns why the subscription to s is no longer a plain
+// watch, or an empty string if it's unchanged since it was loaded.
+func checkSubscription(ctx context.Context, gh *github.Client, s sub) (string, error) {
+	ws, _, err := gh.Activity.GetRepositorySubscription(ctx, s.org, s.repo)
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("checking subscription to %s: %w", s, err)
+	case ws == nil:
+		return "no longer watched", nil
+	case ws.GetIgnored():
+		return "switched to ignoring", nil
+	case !ws.GetSubscribed():
+		return "switched to custom", nil
+	}
+	return "", nil
+}
+
+// unwatchSubs unwatches subs, stopping at the first error. With verify, the
+// subscriptions that changed since they were loaded are skipped and
+// returned as conflicts.
+func unwatchSubs(ctx context.Context, gh *github.Client, subs []sub, verify bool) (unwatched []sub, conflicts []conflict, err error) {
 	for _, s := range subs {
-		_, err := m.gh.Activity.DeleteRepositorySubscription(ctx, s.org, s.repo)
-		if err != nil {
-			return func() tea.Msg {
-				return fmt.Errorf("unwatching %s/%s: %w", s.org, s.repo, err)
+		if verify {
+			reason, err := checkSubscription(ctx, gh, s)
+			if err != nil {
+				return unwatched, conflicts, err
 			}
+			if reason != "" {
+				conflicts = append(conflicts, conflict{s, reason})
+				continue
+			}
+		}
+
+		if _, err := gh.Activity.DeleteRepositorySubscription(ctx, s.org, s.repo); err != nil {
+			return unwatched, conflicts, fmt.Errorf("unwatching %s/%s: %w", s.org, s.repo, err)
 		}
+		unwatched = append(unwatched, s)
 	}
 
-	return m.loadSubs
+	return unwatched, conflicts, nil
+}
+
+func (m model) unwatch(subs []sub) tea.Cmd {
+	return func() tea.Msg {
+		var msg conflictsMsg
+		_, msg.conflicts, msg.err = unwatchSubs(context.TODO(), m.gh, subs, m.verify)
+
+		switch {
+		case len(msg.conflicts) > 0:
+			return msg
+		case msg.err != nil:
+			return msg.err
+		}
+
+		return m.loadSubs()
+	}
 }
 
 type keyMap struct {
-	Quit, Mark, Exec key.Binding
+	Quit, Mark, Suggest, Filter, Stage, Unstage, Tab, Commit key.Binding
 }
 
 func (km keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{km.Mark, km.Exec, km.Quit}
+	return []key.Binding{km.Mark, km.Suggest, km.Filter, km.Stage, km.Tab, km.Commit, km.Quit}
+}
+
+func (km keyMap) StagedHelp() []key.Binding {
+	return []key.Binding{km.Mark, km.Unstage, km.Tab, km.Commit, km.Quit}
 }
 
 func (km keyMap) FullHelp() [][]key.Binding {
@@ -247,10 +909,25 @@ var km = keyMap{
 	Mark: key.NewBinding(
 		key.WithKeys(" "),
 		key.WithHelp("space", "toggle mark")),
+	Suggest: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "mark suggested")),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter")),
 	Quit: key.NewBinding(
 		key.WithKeys("q"),
 		key.WithHelp("q", "quit")),
-	Exec: key.NewBinding(
+	Stage: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "stage")),
+	Unstage: key.NewBinding(
 		key.WithKeys("x"),
-		key.WithHelp("x", "unwatch")),
+		key.WithHelp("x", "unstage")),
+	Tab: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "switch tab")),
+	Commit: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "unwatch staged")),
 }
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// A signal is an independent source of evidence that a subscription might
+// not be worth keeping. Signals fetch whatever they need in Prepare, which
+// returns the check to run against each subscription. Anything a check needs
+// must live in it rather than in the signal, so suggest can run concurrently.
+type signal interface {
+	Name() string
+	Prepare(ctx context.Context, gh *github.Client, subs []sub) (signalCheck, error)
+}
+
+type signalCheck func(s sub) (reason string, ok bool)
+
+var signals = map[string]signal{}
+
+func registerSignal(s signal) {
+	if _, ok := signals[s.Name()]; ok {
+		panic("signal already registered: " + s.Name())
+	}
+	signals[s.Name()] = s
+}
+
+func signalNames() []string {
+	names := make([]string, 0, len(signals))
+	for n := range signals {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	registerSignal(archivedSignal{})
+	registerSignal(staleSignal{after: 365 * 24 * time.Hour})
+	registerSignal(notificationsSignal{window: 30 * 24 * time.Hour, max: 50})
+	registerSignal(contributionSignal{})
+}
+
+type suggestion struct {
+	sub
+	reasons []string
+}
+
+type suggestConfig struct {
+	minSignals int
+	hook       string
+}
+
+func suggestFlags(fs *flag.FlagSet) *suggestConfig {
+	cfg := &suggestConfig{}
+	fs.IntVar(&cfg.minSignals, "min-signals", 2, "minimum number of signals needed to suggest unwatching")
+	fs.StringVar(&cfg.hook, "hook", "", "starlark script that decides which subscriptions to suggest")
+	return cfg
+}
+
+// suggest runs every registered signal and returns the subscriptions that
+// triggered at least minSignals of them, unless the hook says otherwise.
+func suggest(ctx context.Context, gh *github.Client, subs []sub, cfg suggestConfig) ([]suggestion, error) {
+	names := signalNames()
+	checks := make([]signalCheck, len(names))
+	for i, name := range names {
+		check, err := signals[name].Prepare(ctx, gh, subs)
+		if err != nil {
+			return nil, fmt.Errorf("preparing %s signal: %w", name, err)
+		}
+		checks[i] = check
+	}
+
+	reasons := make(map[string][]string, len(subs))
+	for _, s := range subs {
+		for _, check := range checks {
+			if reason, ok := check(s); ok {
+				reasons[s.String()] = append(reasons[s.String()], reason)
+			}
+		}
+	}
+
+	var actions map[string]string
+	if cfg.hook != "" {
+		var err error
+		actions, err = runHook(ctx, cfg.hook, subs, reasons)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var res []suggestion
+	for _, s := range subs {
+		rs := reasons[s.String()]
+
+		switch actions[s.String()] {
+		case actionKeep:
+			continue
+		case actionUnwatch:
+			res = append(res, suggestion{s, append(rs, "hook")})
+		default:
+			if len(rs) > 0 && len(rs) >= cfg.minSignals {
+				res = append(res, suggestion{s, rs})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+type archivedSignal struct{}
+
+func (archivedSignal) Name() string { return "archived" }
+
+func (archivedSignal) Prepare(context.Context, *github.Client, []sub) (signalCheck, error) {
+	return func(s sub) (string, bool) {
+		return "archived", s.archived
+	}, nil
+}
+
+type staleSignal struct {
+	after time.Duration
+}
+
+func (staleSignal) Name() string { return "staleness" }
+
+func (sig staleSignal) Prepare(context.Context, *github.Client, []sub) (signalCheck, error) {
+	return func(s sub) (string, bool) {
+		if s.pushedAt.IsZero() || time.Since(s.pushedAt) < sig.after {
+			return "", false
+		}
+		return fmt.Sprintf("no pushes since %s", s.pushedAt.Format("2006-01-02")), true
+	}, nil
+}
+
+type notificationsSignal struct {
+	window time.Duration
+	max    int
+}
+
+func (notificationsSignal) Name() string { return "notification volume" }
+
+func (sig notificationsSignal) Prepare(ctx context.Context, gh *github.Client, _ []sub) (signalCheck, error) {
+	counts := make(map[string]int)
+
+	opts := &github.NotificationListOptions{
+		All:         true,
+		Since:       time.Now().Add(-sig.window),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		ns, res, err := gh.Activity.ListNotifications(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("fetching page %d of notifications: %w", opts.Page, err)
+		}
+
+		for _, n := range ns {
+			counts[n.GetRepository().GetFullName()]++
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
+	}
+
+	return func(s sub) (string, bool) {
+		n := counts[s.String()]
+		if n < sig.max {
+			return "", false
+		}
+		return fmt.Sprintf("%d notifications in the last %d days", n, int(sig.window.Hours()/24)), true
+	}, nil
+}
+
+// contributionSignal flags repositories where the user has no recent
+// activity. GitHub only returns the last 300 events, so this is a rough
+// approximation of contribution history.
+type contributionSignal struct{}
+
+func (contributionSignal) Name() string { return "contribution history" }
+
+func (contributionSignal) Prepare(ctx context.Context, gh *github.Client, _ []sub) (signalCheck, error) {
+	active := make(map[string]bool)
+
+	u, _, err := gh.Users.Get(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("fetching current user: %w", err)
+	}
+
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		evs, res, err := gh.Activity.ListEventsPerformedByUser(ctx, u.GetLogin(), false, opts)
+		if err != nil {
+			return nil, fmt.Errorf("fetching page %d of user events: %w", opts.Page, err)
+		}
+
+		for _, ev := range evs {
+			active[ev.GetRepo().GetName()] = true
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
+	}
+
+	return func(s sub) (string, bool) {
+		return "no recent activity from you", !active[s.String()]
+	}, nil
+}
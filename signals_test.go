@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	gh := newDemoClient()
+
+	subs, err := getSubs(context.Background(), gh)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Repositories in the demo data that trigger at least two signals.
+	want := []string{
+		"acme/infra",
+		"acme/legacy-portal",
+		"octo-org/design-system",
+		"octo-org/hackathon-2019",
+		"octo-org/monorepo",
+		"octocat/hello-world",
+		"oss-friends/awesome-list",
+		"oss-friends/old-plugin",
+	}
+
+	// Run concurrently to make sure signals don't share state between runs.
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sugs, err := suggest(context.Background(), gh, subs, suggestConfig{minSignals: 2})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			var got []string
+			for _, s := range sugs {
+				if len(s.reasons) < 2 {
+					t.Errorf("%s suggested with only %v", s, s.reasons)
+				}
+				got = append(got, s.String())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRegisterSignalDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic registering a duplicate signal")
+		}
+	}()
+	registerSignal(archivedSignal{})
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+)
+
+// stateDir is where ghunwatch keeps the files it writes between runs.
+var stateDir string
+
+func defaultStateDir() string {
+	if dir := os.Getenv("GHUNWATCH_STATE_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "ghunwatch")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".local", "state", "ghunwatch")
+	}
+	return ".ghunwatch"
+}
+
+func stateDirVar(fs *flag.FlagSet) {
+	fs.StringVar(&stateDir, "state-dir", defaultStateDir(), "directory for files kept between runs")
+}
+
+func openState(name string) (*os.File, error) {
+	return os.Open(filepath.Join(stateDir, name))
+}
+
+func appendState(name string, data []byte) error {
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(stateDir, name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
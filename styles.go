@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+var colorProfiles = map[string]termenv.Profile{
+	"none":      termenv.Ascii,
+	"16":        termenv.ANSI,
+	"256":       termenv.ANSI256,
+	"truecolor": termenv.TrueColor,
+}
+
+// colorFlag is a flag.Value that overrides the detected color profile.
+type colorFlag struct {
+	name    string
+	profile termenv.Profile
+}
+
+func (f *colorFlag) String() string { return f.name }
+
+func (f *colorFlag) Set(name string) error {
+	if name == "auto" {
+		f.name, f.profile = name, termenv.EnvColorProfile()
+		return nil
+	}
+
+	p, ok := colorProfiles[name]
+	if !ok {
+		return fmt.Errorf("unknown color mode %q, must be one of: auto, none, 16, 256, truecolor", name)
+	}
+	f.name, f.profile = name, p
+	return nil
+}
+
+type styles struct {
+	highlight lipgloss.Style
+	hint      lipgloss.Style
+	help      help.Styles
+}
+
+// newStyles picks styles that stay readable with the given color profile.
+// Subtle grays are only used when the terminal can render them faithfully.
+func newStyles(p termenv.Profile) styles {
+	st := styles{
+		hint: lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1),
+		help: help.New().Styles,
+	}
+
+	switch p {
+	case termenv.Ascii:
+		plain := lipgloss.NewStyle()
+		st.highlight = plain.Copy().Reverse(true)
+		st.help = help.Styles{
+			Ellipsis:       plain,
+			ShortKey:       plain.Copy().Bold(true),
+			ShortDesc:      plain,
+			ShortSeparator: plain,
+			FullKey:        plain.Copy().Bold(true),
+			FullDesc:       plain,
+			FullSeparator:  plain,
+		}
+
+	case termenv.ANSI:
+		st.highlight = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("15")).
+			Background(lipgloss.Color("4"))
+		st.hint = st.hint.BorderForeground(lipgloss.Color("5"))
+		key := lipgloss.NewStyle().Bold(true)
+		desc := lipgloss.NewStyle()
+		sep := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+		st.help = help.Styles{
+			Ellipsis:       sep,
+			ShortKey:       key,
+			ShortDesc:      desc,
+			ShortSeparator: sep,
+			FullKey:        key,
+			FullDesc:       desc,
+			FullSeparator:  sep,
+		}
+
+	case termenv.ANSI256:
+		st.highlight = lipgloss.NewStyle().Background(lipgloss.AdaptiveColor{Light: "253", Dark: "237"})
+		st.hint = st.hint.BorderForeground(lipgloss.Color("170"))
+
+	default:
+		st.highlight = lipgloss.NewStyle().Background(lipgloss.AdaptiveColor{Light: "#DDE", Dark: "#334"})
+		st.hint = st.hint.BorderForeground(lipgloss.Color("#C6A0F6"))
+	}
+
+	return st
+}
@@ -0,0 +1,83 @@
+package main
+
+import "fmt"
+
+type tutorialStep struct {
+	hint string
+	done func(m model) bool
+}
+
+var tutorialSteps = []tutorialStep{
+	{
+		hint: "Press / and type part of a name to filter the list, then enter to stop typing.",
+		done: func(m model) bool {
+			return !m.filtering && len(m.tables[tabWatching].GetVisibleRows()) < len(m.rows(tabWatching))
+		},
+	},
+	{
+		hint: "Press space to mark the highlighted repository, or s to mark the suggested ones.",
+		done: func(m model) bool { return len(m.marks()) > 0 },
+	},
+	{
+		hint: "Press x to move the marked repositories to the staged tab. Nothing is unwatched yet.",
+		done: func(m model) bool { return len(m.staged) > 0 },
+	},
+	{
+		hint: "Press tab to review what's staged.",
+		done: func(m model) bool { return m.tab == tabStaged },
+	},
+	{
+		hint: "Changed your mind? Mark a staged repository and press x to put it back.",
+		done: func(m model) bool { return len(m.staged) < m.tutorial.peakStaged },
+	},
+	{
+		hint: "Stage something again and press c to unwatch it. This is demo data, nothing real changes.",
+		done: func(m model) bool { return len(m.subs) < m.tutorial.peakSubs },
+	},
+	{
+		hint: "That's all! Keep playing with the demo data, or press q to quit.",
+		done: func(model) bool { return false },
+	},
+}
+
+type tutorialState struct {
+	enabled bool
+	// step is the first step that isn't done yet. Steps are checked all the
+	// time and not only when current, so doing things out of order, like
+	// never filtering, doesn't stop the tutorial from advancing.
+	step       int
+	done       []bool
+	peakStaged int
+	peakSubs   int
+}
+
+func (m *model) advanceTutorial() {
+	t := &m.tutorial
+	if len(m.staged) > t.peakStaged {
+		t.peakStaged = len(m.staged)
+	}
+	if len(m.subs) > t.peakSubs {
+		t.peakSubs = len(m.subs)
+	}
+
+	if t.done == nil {
+		t.done = make([]bool, len(tutorialSteps))
+	}
+	for i, s := range tutorialSteps {
+		if !t.done[i] && s.done(*m) {
+			t.done[i] = true
+		}
+	}
+
+	t.step = 0
+	for t.step < len(tutorialSteps)-1 && t.done[t.step] {
+		t.step++
+	}
+}
+
+func (m model) tutorialHint() string {
+	if !m.tutorial.enabled {
+		return ""
+	}
+	return m.styles.hint.Render(fmt.Sprintf("Tutorial %d/%d: %s", m.tutorial.step+1, len(tutorialSteps), tutorialSteps[m.tutorial.step].hint))
+}
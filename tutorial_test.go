@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+func TestTutorialOutOfOrder(t *testing.T) {
+	m := newModel(newDemoClient(), suggestConfig{minSignals: 2}, newStyles(termenv.Ascii), false, true).(model)
+
+	update := func(msg tea.Msg) tea.Cmd {
+		t.Helper()
+
+		res, cmd := m.Update(msg)
+		m = res.(model)
+		if m.err != nil {
+			t.Fatal(m.err)
+		}
+		return cmd
+	}
+	press := func(keys ...string) {
+		t.Helper()
+
+		for _, k := range keys {
+			switch k {
+			case "tab":
+				update(tea.KeyMsg{Type: tea.KeyTab})
+			case "enter":
+				update(tea.KeyMsg{Type: tea.KeyEnter})
+			case "s", "c":
+				// Suggesting and unwatching happen in the background.
+				update(update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})())
+			default:
+				update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+			}
+		}
+	}
+
+	update(m.loadSubs())
+
+	// Skip filtering and do everything else.
+	press("s", "x", "tab", " ", "x", "c")
+
+	if m.tutorial.step != 0 {
+		t.Errorf("got step %d, want the filter step to still be pending", m.tutorial.step)
+	}
+	for i, done := range m.tutorial.done[1 : len(tutorialSteps)-1] {
+		if !done {
+			t.Errorf("step %d isn't done", i+2)
+		}
+	}
+
+	press("tab", "/", "a", "c", "m", "e", "enter")
+
+	if want := len(tutorialSteps) - 1; m.tutorial.step != want {
+		t.Errorf("got step %d, want %d", m.tutorial.step, want)
+	}
+}